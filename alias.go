@@ -0,0 +1,138 @@
+package notes
+
+import (
+	"github.com/pkg/errors"
+	"strconv"
+	"strings"
+)
+
+// splitFields splits s on spaces and tabs (other whitespace such as newlines is kept inside fields),
+// except that a double-quoted substring
+// (e.g. `--title "Weekly Notes"`) is kept together as a single field with its quotes stripped
+func splitFields(s string) []string {
+	var fields []string
+	var cur strings.Builder
+	inQuote := false
+	hasField := false
+
+	flush := func() {
+		if hasField {
+			fields = append(fields, cur.String())
+			cur.Reset()
+			hasField = false
+		}
+	}
+
+	for _, r := range s {
+		switch {
+		case r == '"':
+			inQuote = !inQuote
+			hasField = true
+		case !inQuote && (r == ' ' || r == '\t'):
+			flush()
+		default:
+			cur.WriteRune(r)
+			hasField = true
+		}
+	}
+	flush()
+
+	return fields
+}
+
+// expandPlaceholders splits an alias expansion into argv, substituting "$@" with every one of rest and
+// "$1", "$2", ... with the matching positional entry of rest. Tokens that aren't placeholders are kept
+// as-is. referencedRest reports whether any "$@" or "$N" placeholder was actually substituted, so the
+// caller knows whether rest was already consumed here or still needs appending
+func expandPlaceholders(expansion string, rest []string) (argv []string, referencedRest bool) {
+	fields := splitFields(expansion)
+	argv = make([]string, 0, len(fields)+len(rest))
+	for _, f := range fields {
+		if f == "$@" {
+			argv = append(argv, rest...)
+			referencedRest = true
+			continue
+		}
+		if len(f) >= 2 && f[0] == '$' {
+			if n, err := strconv.Atoi(f[1:]); err == nil && n >= 1 && n <= len(rest) {
+				argv = append(argv, rest[n-1])
+				referencedRest = true
+				continue
+			}
+		}
+		argv = append(argv, f)
+	}
+	return argv, referencedRest
+}
+
+// globalValueFlags lists notes-cli's global flags which consume a separate following argument as their
+// value (as opposed to "--flag=value" or boolean flags), so the scan for the alias candidate in
+// expandAliasesVisited can skip over both the flag and its value instead of mistaking the value for the
+// alias name
+var globalValueFlags = map[string]bool{
+	"--home":        true,
+	"--working-dir": true,
+	"-W":            true,
+}
+
+// expandAliases rewrites args, replacing the first non-flag token with its expansion from aliases, if
+// any. This must run before kingpin.Application.Parse() sees the arguments, so aliases work for every
+// subcommand. When an alias' expansion doesn't reference "$@" or any "$N" placeholder, the remaining
+// original args are appended after the expansion, as if the alias were the subcommand name itself.
+// An expansion whose command position holds another alias is expanded again, but reaching an alias
+// that was already expanded is rejected as an error, to avoid infinite recursion
+func expandAliases(args []string, aliases map[string]string) ([]string, error) {
+	return expandAliasesVisited(args, aliases, map[string]bool{})
+}
+
+func expandAliasesVisited(args []string, aliases map[string]string, visited map[string]bool) ([]string, error) {
+	idx := -1
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		if a == "--" {
+			// Everything after the end-of-options terminator is a literal positional argument, never
+			// an alias to expand
+			break
+		}
+		if strings.HasPrefix(a, "-") {
+			if globalValueFlags[a] {
+				// Skip the flag's value too, so it's never mistaken for the alias candidate
+				i++
+			}
+			continue
+		}
+		idx = i
+		break
+	}
+	if idx == -1 {
+		return args, nil
+	}
+
+	name := args[idx]
+	expansion, ok := aliases[name]
+	if !ok {
+		return args, nil
+	}
+	if visited[name] {
+		// A repeat of the same alias name in the command position is always treated as a cycle, even in
+		// the rare case where it's actually a distinct positional argument that happens to reuse an
+		// alias name (e.g. an alias expanding to "") shifted into that position. Aliases and note
+		// arguments sharing a name is expected to be uncommon enough that flagging it is the safer default
+		return nil, errors.Errorf("Recursive alias '%s'. Its expansion leads back to an alias already expanded", name)
+	}
+	visited[name] = true
+
+	rest := args[idx+1:]
+	expanded, referencedRest := expandPlaceholders(expansion, rest)
+
+	argv := make([]string, 0, len(args)+len(expanded))
+	argv = append(argv, args[:idx]...)
+	argv = append(argv, expanded...)
+	if !referencedRest {
+		// The expansion never referenced "$@" or any "$N", so it's a plain command shorthand (e.g.
+		// "journal" -> "new journal daily") and the original trailing args still belong after it
+		argv = append(argv, rest...)
+	}
+
+	return expandAliasesVisited(argv, aliases, visited)
+}
@@ -0,0 +1,110 @@
+package notes
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitFields(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want []string
+	}{
+		{"new journal $@", []string{"new", "journal", "$@"}},
+		{`new --title "Weekly Notes" work`, []string{"new", "--title", "Weekly Notes", "work"}},
+		{"", nil},
+		{"  a   b  ", []string{"a", "b"}},
+	} {
+		got := splitFields(tc.in)
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("splitFields(%q) = %v, wanted %v", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestExpandAliasesNoMatch(t *testing.T) {
+	args := []string{"new", "work", "todo"}
+	got, err := expandAliases(args, map[string]string{"journal": "new journal"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, args) {
+		t.Errorf("got %v, wanted unchanged %v", got, args)
+	}
+}
+
+func TestExpandAliasesSimple(t *testing.T) {
+	got, err := expandAliases([]string{"journal"}, map[string]string{"journal": "new journal daily"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"new", "journal", "daily"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, wanted %v", got, want)
+	}
+}
+
+func TestExpandAliasesAppendsRemainingArgsWithoutDollarAt(t *testing.T) {
+	got, err := expandAliases([]string{"j", "extra"}, map[string]string{"j": "new journal"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"new", "journal", "extra"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, wanted %v", got, want)
+	}
+}
+
+func TestExpandAliasesDollarAt(t *testing.T) {
+	got, err := expandAliases([]string{"j", "work", "todo"}, map[string]string{"j": "new $@"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"new", "work", "todo"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, wanted %v", got, want)
+	}
+}
+
+func TestExpandAliasesPositional(t *testing.T) {
+	got, err := expandAliases([]string{"j", "work", "todo"}, map[string]string{"j": "new $2 $1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"new", "todo", "work"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, wanted %v", got, want)
+	}
+}
+
+func TestExpandAliasesRejectsRecursion(t *testing.T) {
+	_, err := expandAliases([]string{"a"}, map[string]string{"a": "b", "b": "a"})
+	if err == nil {
+		t.Fatal("expected an error for recursive alias expansion")
+	}
+}
+
+func TestExpandAliasesSkipsDoubleDashTerminator(t *testing.T) {
+	args := []string{"--", "journal"}
+	got, err := expandAliases(args, map[string]string{"journal": "new journal daily"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, args) {
+		t.Errorf("got %v, wanted unchanged %v (literal arg after --)", got, args)
+	}
+}
+
+func TestExpandAliasesSkipsGlobalValueFlags(t *testing.T) {
+	got, err := expandAliases(
+		[]string{"--working-dir", "/some/dir", "journal"},
+		map[string]string{"journal": "new journal daily"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"--working-dir", "/some/dir", "new", "journal", "daily"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, wanted %v", got, want)
+	}
+}
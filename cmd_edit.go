@@ -0,0 +1,230 @@
+package notes
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/pkg/errors"
+	"gopkg.in/alecthomas/kingpin.v2"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// editConfirmThreshold is the number of matched notes above which EditCmd asks for
+// confirmation before opening them all in the editor at once
+const editConfirmThreshold = 2
+
+// EditCmd represents `notes edit` command. Each public fields represent options of the command
+type EditCmd struct {
+	cli    *kingpin.CmdClause
+	Config *Config
+	// Category narrows matches down to notes filed under this category. Empty means any category
+	Category string
+	// Tag narrows matches down to notes which have this tag. Empty means any tag
+	Tag string
+	// Pattern is a glob pattern matched against the note's file name. Empty means any file name
+	Pattern string
+	// Since narrows matches down to notes modified on or after this date, given as "YYYY-MM-DD". Empty
+	// means no lower bound
+	Since string
+	// Until narrows matches down to notes modified on or before this date, given as "YYYY-MM-DD". Empty
+	// means no upper bound
+	Until string
+	// Force is a flag equivalent to --force/-f. When set, the confirmation prompt is skipped
+	Force bool
+}
+
+func (cmd *EditCmd) defineCLI(app *kingpin.Application) {
+	cmd.cli = app.Command("edit", "Open notes matching filter in your editor at once")
+	cmd.cli.Flag("category", "Only consider notes in this category").StringVar(&cmd.Category)
+	cmd.cli.Flag("tag", "Only consider notes which have this tag").StringVar(&cmd.Tag)
+	cmd.cli.Flag("pattern", "Only consider notes whose file name matches this glob pattern").StringVar(&cmd.Pattern)
+	cmd.cli.Flag("since", "Only consider notes modified on or after this date, as 'YYYY-MM-DD'").StringVar(&cmd.Since)
+	cmd.cli.Flag("until", "Only consider notes modified on or before this date, as 'YYYY-MM-DD'").StringVar(&cmd.Until)
+	cmd.cli.Flag("force", "Do not ask for confirmation before opening many notes").Short('f').BoolVar(&cmd.Force)
+}
+
+func (cmd *EditCmd) matchesCmdline(cmdline string) bool {
+	return cmd.cli.FullCommand() == cmdline
+}
+
+// hasTag reports whether the note file at path contains a line starting with "tags:" (such as the
+// frontmatter's tags line) listing tag. Lines outside the frontmatter are checked too
+func hasTag(path, tag string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, errors.Wrapf(err, "Cannot open note '%s'", path)
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if !strings.HasPrefix(line, "tags:") {
+			continue
+		}
+		for _, t := range strings.Split(strings.TrimPrefix(line, "tags:"), ",") {
+			if strings.TrimSpace(t) == tag {
+				return true, nil
+			}
+		}
+	}
+	if err := s.Err(); err != nil {
+		return false, errors.Wrapf(err, "Cannot read note '%s'", path)
+	}
+
+	return false, nil
+}
+
+// dateFilterLayout is the expected format of EditCmd's --since/--until flags
+const dateFilterLayout = "2006-01-02"
+
+// parseDateFilter parses s as a --since/--until date, returning the zero time when s is empty
+func parseDateFilter(flag, s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(dateFilterLayout, s)
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "Invalid --%s date '%s'. Please use 'YYYY-MM-DD'", flag, s)
+	}
+	return t, nil
+}
+
+// findNotes walks the home directory and collects paths of notes matching the command's filters
+func (cmd *EditCmd) findNotes() ([]string, error) {
+	root := cmd.Config.HomePath
+	if cmd.Category != "" {
+		// cmd.Category may become an absolute path once resolved against Config.WorkingDir (when
+		// --working-dir/-W was explicitly given), so it must be re-expressed relative to HomePath before
+		// being joined under it again, same as resolveCategory does for `new`'s category argument
+		category, err := cmd.Config.resolveHomeRelative(cmd.Category)
+		if err != nil {
+			return nil, err
+		}
+		root = filepath.Join(root, category)
+	}
+
+	since, err := parseDateFilter("since", cmd.Since)
+	if err != nil {
+		return nil, err
+	}
+	until, err := parseDateFilter("until", cmd.Until)
+	if err != nil {
+		return nil, err
+	}
+	if cmd.Until != "" {
+		// --until is inclusive of the whole given day, so compare against the instant just before the
+		// next day starts
+		until = until.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+
+	var paths []string
+	err = filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		if filepath.Ext(path) != ".md" {
+			return nil
+		}
+
+		if cmd.Pattern != "" {
+			matched, err := filepath.Match(cmd.Pattern, filepath.Base(path))
+			if err != nil {
+				return errors.Wrapf(err, "Invalid glob pattern '%s'", cmd.Pattern)
+			}
+			if !matched {
+				return nil
+			}
+		}
+
+		if cmd.Since != "" && info.ModTime().Before(since) {
+			return nil
+		}
+		if cmd.Until != "" && info.ModTime().After(until) {
+			return nil
+		}
+
+		if cmd.Tag != "" {
+			ok, err := hasTag(path, cmd.Tag)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return nil
+			}
+		}
+
+		paths = append(paths, path)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "Cannot search notes")
+	}
+
+	return paths, nil
+}
+
+// confirmOpen asks the user on the TTY (read from r) whether it's OK to open n notes at once
+func confirmOpen(n int, r io.Reader) (bool, error) {
+	fmt.Fprintf(os.Stderr, "Open %d notes? [y/N]: ", n)
+	s := bufio.NewScanner(r)
+	if !s.Scan() {
+		if err := s.Err(); err != nil {
+			return false, errors.Wrap(err, "Cannot read confirmation from stdin")
+		}
+		return false, nil
+	}
+	a := strings.ToLower(strings.TrimSpace(s.Text()))
+	return a == "y" || a == "yes", nil
+}
+
+// Do runs `notes edit` command and returns an error if occurs
+func (cmd *EditCmd) Do() error {
+	if cmd.Config.EditorCmd == "" {
+		return errors.New("No editor is set. Please set $NOTES_CLI_EDITOR or $EDITOR")
+	}
+
+	paths, err := cmd.findNotes()
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		return errors.New("No notes matched given filters")
+	}
+
+	if len(paths) > editConfirmThreshold && !cmd.Force {
+		ok, err := confirmOpen(len(paths), os.Stdin)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Fprintln(os.Stderr, "Canceled")
+			return nil
+		}
+	}
+
+	fields := strings.Fields(cmd.Config.EditorCmd)
+	if len(fields) == 0 {
+		return errors.New("Editor command is blank. Please set $NOTES_CLI_EDITOR or $EDITOR")
+	}
+	args := append(fields[1:], paths...)
+	c := exec.Command(fields[0], args...)
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	if err := c.Run(); err != nil {
+		return errors.Wrapf(err, "Cannot open %d notes with command '%s'", len(paths), cmd.Config.EditorCmd)
+	}
+
+	return nil
+}
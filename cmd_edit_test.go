@@ -0,0 +1,141 @@
+package notes
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeTestNote(t *testing.T, path, tags string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	body := "---\ntitle: test\ntags: " + tags + "\n---\n"
+	if err := ioutil.WriteFile(path, []byte(body), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEditCmdFindNotes(t *testing.T) {
+	home, err := ioutil.TempDir("", "notes-cli-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(home)
+
+	writeTestNote(t, filepath.Join(home, "work", "todo.md"), "work, urgent")
+	writeTestNote(t, filepath.Join(home, "work", "memo.md"), "work")
+	writeTestNote(t, filepath.Join(home, "private", "diary.md"), "private")
+	if err := ioutil.WriteFile(filepath.Join(home, "work", "README"), []byte("not a note"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	old := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	if err := os.Chtimes(filepath.Join(home, "private", "diary.md"), old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	config := &Config{HomePath: home}
+
+	for _, tc := range []struct {
+		name string
+		cmd  *EditCmd
+		want []string
+	}{
+		{
+			"no filter",
+			&EditCmd{Config: config},
+			[]string{"diary.md", "memo.md", "todo.md"},
+		},
+		{
+			"category filter",
+			&EditCmd{Config: config, Category: "work"},
+			[]string{"memo.md", "todo.md"},
+		},
+		{
+			"tag filter",
+			&EditCmd{Config: config, Tag: "urgent"},
+			[]string{"todo.md"},
+		},
+		{
+			"pattern filter",
+			&EditCmd{Config: config, Pattern: "todo.*"},
+			[]string{"todo.md"},
+		},
+		{
+			"no match",
+			&EditCmd{Config: config, Tag: "nonexistent"},
+			nil,
+		},
+		{
+			"since filter excludes notes modified before it",
+			&EditCmd{Config: config, Since: "2021-01-01"},
+			[]string{"memo.md", "todo.md"},
+		},
+		{
+			"until filter excludes notes modified after it",
+			&EditCmd{Config: config, Until: "2020-01-01"},
+			[]string{"diary.md"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			paths, err := tc.cmd.findNotes()
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := make([]string, 0, len(paths))
+			for _, p := range paths {
+				got = append(got, filepath.Base(p))
+			}
+			sort.Strings(got)
+			if len(got) != len(tc.want) {
+				t.Fatalf("got %v, wanted %v", got, tc.want)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Fatalf("got %v, wanted %v", got, tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestEditCmdFindNotesInvalidDateFilter(t *testing.T) {
+	home, err := ioutil.TempDir("", "notes-cli-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(home)
+
+	cmd := &EditCmd{Config: &Config{HomePath: home}, Since: "not-a-date"}
+	if _, err := cmd.findNotes(); err == nil {
+		t.Fatal("expected an error for an invalid --since date")
+	}
+}
+
+func TestConfirmOpen(t *testing.T) {
+	for _, tc := range []struct {
+		input string
+		want  bool
+	}{
+		{"y\n", true},
+		{"yes\n", true},
+		{"Y\n", true},
+		{"n\n", false},
+		{"\n", false},
+		{"", false},
+	} {
+		ok, err := confirmOpen(3, strings.NewReader(tc.input))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok != tc.want {
+			t.Errorf("confirmOpen(%q) = %v, wanted %v", tc.input, ok, tc.want)
+		}
+	}
+}
@@ -6,6 +6,9 @@ import (
 	"gopkg.in/alecthomas/kingpin.v2"
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 )
 
 // NewCmd represents `notes new` command. Each public fields represent options of the command
@@ -20,6 +23,25 @@ type NewCmd struct {
 	Tags string
 	// NoInline is a flag equivalent to --no-inline-input
 	NoInline bool
+	// Template is a file path to a template rendered as the note's initial body, equivalent to --template.
+	// When empty, the per-category and shared template lookup paths are tried instead
+	Template string
+	// Title is the note's title, available to its template as {{.Title}}
+	Title string
+	// Date is the note's date, available to its template as {{.Date}}. Defaults to today when empty
+	Date string
+	// Extra is a mapsep-style "key=val,key2=val2" string of extra template variables
+	Extra string
+	// PrintPath is a flag equivalent to --print-path/-p. Prints the created note's path and skips
+	// launching the editor
+	PrintPath bool
+	// DryRun is a flag equivalent to --dry-run/-n. Renders the template to stdout and the intended path
+	// to stderr, creating nothing
+	DryRun bool
+	// Interactive is a flag equivalent to --interactive/-i. Reads all of stdin before creating the note
+	// and uses it as the note's body (or as {{.Content}} when combined with --template), instead of the
+	// post-hoc fallbackInput prompt
+	Interactive bool
 }
 
 func (cmd *NewCmd) defineCLI(app *kingpin.Application) {
@@ -28,6 +50,13 @@ func (cmd *NewCmd) defineCLI(app *kingpin.Application) {
 	cmd.cli.Arg("filename", "Name of memo").Required().StringVar(&cmd.Filename)
 	cmd.cli.Arg("tags", "Comma-separated tags of memo").StringVar(&cmd.Tags)
 	cmd.cli.Flag("no-inline-input", "Does not request inline input even if no editor is set").BoolVar(&cmd.NoInline)
+	cmd.cli.Flag("template", "Path to a template file rendered as the note's initial body").StringVar(&cmd.Template)
+	cmd.cli.Flag("title", "Title of the note, available to its template as {{.Title}}").StringVar(&cmd.Title)
+	cmd.cli.Flag("date", "Date of the note, available to its template as {{.Date}}. Defaults to today").StringVar(&cmd.Date)
+	cmd.cli.Flag("extra", "Extra template variables as 'key=val,key2=val2'").StringVar(&cmd.Extra)
+	cmd.cli.Flag("print-path", "Print the created note's path and skip opening the editor").Short('p').BoolVar(&cmd.PrintPath)
+	cmd.cli.Flag("dry-run", "Render the template to stdout and print the intended path to stderr. Creates nothing").Short('n').BoolVar(&cmd.DryRun)
+	cmd.cli.Flag("interactive", "Read stdin before creating the note and use it as the note's body").Short('i').BoolVar(&cmd.Interactive)
 }
 
 func (cmd *NewCmd) matchesCmdline(cmdline string) bool {
@@ -55,30 +84,112 @@ func (cmd *NewCmd) fallbackInput(note *Note) error {
 	return nil
 }
 
+// resolveCategory returns Category resolved against Config.WorkingDir and re-expressed relative to
+// Config.HomePath, so it's always in the form NewNote expects to join under HomePath, even when
+// --working-dir/-W or $NOTES_CLI_WORKING_DIR was explicitly given. When WorkingDir was never set, a
+// relative Category is returned unchanged; an absolute one is still re-expressed relative to HomePath
+// (or rejected when it lies outside HomePath)
+func (cmd *NewCmd) resolveCategory() (string, error) {
+	return cmd.Config.resolveHomeRelative(cmd.Category)
+}
+
+// resolveFilename returns Filename resolved against Config.WorkingDir the same way resolveCategory
+// resolves Category
+func (cmd *NewCmd) resolveFilename() (string, error) {
+	return cmd.Config.resolveHomeRelative(cmd.Filename)
+}
+
+// renderBody resolves and renders the note's template, if any is found for category, with content as
+// its {{.Content}} variable. When no template applies, content itself is used as the body verbatim, so
+// that --interactive still works without --template
+func (cmd *NewCmd) renderBody(category, filename, content string) (string, error) {
+	tmpl, err := findTemplate(cmd.Template, category, cmd.Config)
+	if err != nil {
+		return "", err
+	}
+	if tmpl == "" {
+		return content, nil
+	}
+
+	extra, err := parseExtra(cmd.Extra)
+	if err != nil {
+		return "", err
+	}
+
+	date := cmd.Date
+	if date == "" {
+		date = time.Now().Format("2006-01-02")
+	}
+
+	return renderTemplate(tmpl, &templateVars{
+		Title:    cmd.Title,
+		Category: category,
+		Tags:     cmd.Tags,
+		Filename: filename,
+		Date:     date,
+		ID:       strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename)),
+		Content:  content,
+		Extra:    extra,
+	})
+}
+
 // Do runs `notes new` command and returns an error if occurs
 func (cmd *NewCmd) Do() error {
-	git := NewGit(cmd.Config)
+	category, err := cmd.resolveCategory()
+	if err != nil {
+		return err
+	}
+	filename, err := cmd.resolveFilename()
+	if err != nil {
+		return err
+	}
+
+	var content string
+	if cmd.Interactive {
+		b, err := ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			return errors.Wrap(err, "Cannot read from stdin")
+		}
+		content = string(b)
+	}
+
+	body, err := cmd.renderBody(category, filename, content)
+	if err != nil {
+		return err
+	}
 
-	note, err := NewNote(cmd.Category, cmd.Tags, cmd.Filename, "", cmd.Config)
+	note, err := NewNote(category, cmd.Tags, filename, body, cmd.Config)
 	if err != nil {
 		return err
 	}
 
+	if cmd.DryRun {
+		fmt.Print(body)
+		fmt.Fprintln(os.Stderr, note.FilePath())
+		return nil
+	}
+
 	if err := note.Create(); err != nil {
 		return err
 	}
 
+	git := NewGit(cmd.Config)
 	if git != nil {
 		if err := git.Init(); err != nil {
 			return err
 		}
 	}
 
+	if cmd.PrintPath {
+		fmt.Println(note.FilePath())
+		return nil
+	}
+
 	if err := note.Open(); err != nil {
-		if !cmd.NoInline {
+		// The EOF-prompt fallback reads stdin itself, so it cannot run when --interactive already
+		// consumed stdin as the note's body
+		if !cmd.NoInline && !cmd.Interactive {
 			fmt.Fprintf(os.Stderr, "Note: %s\n", err)
-		}
-		if !cmd.NoInline {
 			return cmd.fallbackInput(note)
 		}
 		// Final fallback is only showing the path to the note. Then users can open it by themselves.
@@ -27,15 +27,33 @@ type Config struct {
 	EditorCmd string
 	// PagerCmd is a command for paging output from 'list' subcommand. If $NOTES_CLI_PAGER is set, it is used.
 	PagerCmd string
+	// WorkingDir is a file path used as the base directory to resolve relative paths given on the command
+	// line (category, filename, list filters, ...), but only when WorkingDirSet is true. Note that this is
+	// independent of HomePath, which always points at the notebook root
+	WorkingDir string
+	// WorkingDirSet is true when WorkingDir was explicitly given via --working-dir/-W or
+	// $NOTES_CLI_WORKING_DIR. When false, WorkingDir merely holds the process' current directory and
+	// relative command line paths keep resolving against HomePath as they always have, so users who never
+	// opted in to --working-dir see no change in behavior
+	WorkingDirSet bool
+	// Aliases maps a user-defined command name to the argv it expands to. Loaded from the config file
+	Aliases map[string]string
+	// TemplateDirs is a list of additional directories searched for shared templates, in order, before
+	// falling back to the default templates directory under configPath(). Loaded from the config file
+	TemplateDirs []string
 }
 
-func homePath() (string, error) {
+func homePath(override string) (string, error) {
 	u, err := user.Current()
 	if err != nil {
 		return "", errors.Wrap(err, "Cannot locate home directory. Please set $NOTES_CLI_HOME")
 	}
 
-	if env := os.Getenv("NOTES_CLI_HOME"); env != "" {
+	env := override
+	if env == "" {
+		env = os.Getenv("NOTES_CLI_HOME")
+	}
+	if env != "" {
 		if strings.HasPrefix(env, "~"+string(filepath.Separator)) {
 			env = filepath.Join(u.HomeDir, env[2:])
 		}
@@ -47,6 +65,10 @@ func homePath() (string, error) {
 	}
 
 	if runtime.GOOS == "windows" {
+		if env := os.Getenv("LOCALAPPDATA"); env != "" {
+			return filepath.Join(env, "notes-cli"), nil
+		}
+		// APPLOCALDATA was a typo for LOCALAPPDATA. Kept for backward compatibility with existing setups
 		if env := os.Getenv("APPLOCALDATA"); env != "" {
 			return filepath.Join(env, "notes-cli"), nil
 		}
@@ -70,34 +92,92 @@ func gitPath() string {
 	return exe
 }
 
-func editorCmd() string {
+func editorCmd(fromFile string) string {
 	if env, ok := os.LookupEnv("NOTES_CLI_EDITOR"); ok {
 		return env
 	}
 	if env, ok := os.LookupEnv("EDITOR"); ok {
 		return env
 	}
-	return ""
+	return fromFile
+}
+
+// workingDirPath resolves Config.WorkingDir and reports whether it was explicitly given (via override or
+// $NOTES_CLI_WORKING_DIR) as opposed to defaulting to the process' current directory
+func workingDirPath(override string) (dir string, explicit bool, err error) {
+	env := override
+	if env == "" {
+		env = os.Getenv("NOTES_CLI_WORKING_DIR")
+	}
+	if env != "" {
+		return filepath.Clean(env), true, nil
+	}
+
+	d, err := os.Getwd()
+	if err != nil {
+		return "", false, errors.Wrap(err, "Cannot locate current directory. Please set $NOTES_CLI_WORKING_DIR")
+	}
+	return d, false, nil
 }
 
-func pagerCmd() string {
+func pagerCmd(fromFile string) string {
 	if env, ok := os.LookupEnv("NOTES_CLI_PAGER"); ok {
 		return env
 	}
 	if env, ok := os.LookupEnv("PAGER"); ok {
 		return env
 	}
+	if fromFile != "" {
+		return fromFile
+	}
 	if _, err := exec.LookPath("less"); err == nil {
 		return "less -R -F -X"
 	}
 	return ""
 }
 
-// NewConfig creates a new Config instance by looking the user's environment. GitPath and EditorPath
-// may be empty when proper configuration is not found. When home directory path cannot be located,
-// this function returns an error
-func NewConfig() (*Config, error) {
-	h, err := homePath()
+// resolveWorkingDir resolves a relative command line path (category, filename, list filter, ...) against
+// c.WorkingDir. It returns path unchanged when path is already absolute, or when c.WorkingDirSet is false
+// (the user never opted in via --working-dir/-W or $NOTES_CLI_WORKING_DIR), keeping the original
+// implicit-HomePath behavior for everyone who hasn't opted in
+func (c *Config) resolveWorkingDir(path string) string {
+	if !c.WorkingDirSet || path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(c.WorkingDir, path)
+}
+
+// resolveHomeRelative resolves path against c.WorkingDir via resolveWorkingDir, then re-expresses the
+// result relative to c.HomePath. This is the form category/filename arguments must be in before being
+// handed to anything that joins them under HomePath again (note creation, per-category template lookup,
+// `edit`'s category filter, ...) — resolveWorkingDir alone can turn a relative path into an absolute one,
+// which would otherwise double up with HomePath into a path that doesn't exist. Returns an error if path
+// resolves outside of HomePath entirely
+func (c *Config) resolveHomeRelative(path string) (string, error) {
+	joined := c.resolveWorkingDir(path)
+	if !filepath.IsAbs(joined) {
+		return joined, nil
+	}
+
+	rel, err := filepath.Rel(c.HomePath, joined)
+	if err != nil {
+		return "", errors.Wrapf(err, "Cannot resolve '%s' against notebook home '%s'", path, c.HomePath)
+	}
+	if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", errors.Errorf("'%s' resolves to '%s', which is outside the notebook home '%s'", path, joined, c.HomePath)
+	}
+
+	return rel, nil
+}
+
+// NewConfig creates a new Config instance by looking the user's environment. homeOverride and
+// workingDirOverride take precedence over $NOTES_CLI_HOME and $NOTES_CLI_WORKING_DIR respectively and
+// are intended to be set from the `--home` and `--working-dir` global flags; pass "" to fall back to the
+// environment (or its own defaults). GitPath and EditorCmd may be empty when proper configuration is
+// not found. When home directory path or working directory cannot be located, this function returns an
+// error
+func NewConfig(homeOverride, workingDirOverride string) (*Config, error) {
+	h, err := homePath(homeOverride)
 	if err != nil {
 		return nil, err
 	}
@@ -107,5 +187,24 @@ func NewConfig() (*Config, error) {
 		return nil, errors.Wrapf(err, "Could not create home '%s'", h)
 	}
 
-	return &Config{h, gitPath(), editorCmd(), pagerCmd()}, nil
+	w, wSet, err := workingDirPath(workingDirOverride)
+	if err != nil {
+		return nil, err
+	}
+
+	fc, err := loadFileConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Config{
+		HomePath:      h,
+		GitPath:       gitPath(),
+		EditorCmd:     editorCmd(fc.Editor),
+		PagerCmd:      pagerCmd(fc.Pager),
+		WorkingDir:    w,
+		WorkingDirSet: wSet,
+		Aliases:       fc.Aliases,
+		TemplateDirs:  fc.Templates,
+	}, nil
 }
@@ -0,0 +1,133 @@
+package notes
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestResolveWorkingDir(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		config *Config
+		path   string
+		want   string
+	}{
+		{
+			"not set leaves path unchanged",
+			&Config{WorkingDir: "/home/alice/cwd", WorkingDirSet: false},
+			"work",
+			"work",
+		},
+		{
+			"set joins relative path",
+			&Config{WorkingDir: "/home/alice/cwd", WorkingDirSet: true},
+			"work",
+			"/home/alice/cwd/work",
+		},
+		{
+			"set leaves absolute path unchanged",
+			&Config{WorkingDir: "/home/alice/cwd", WorkingDirSet: true},
+			"/elsewhere/work",
+			"/elsewhere/work",
+		},
+		{
+			"set leaves empty path unchanged",
+			&Config{WorkingDir: "/home/alice/cwd", WorkingDirSet: true},
+			"",
+			"",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.config.resolveWorkingDir(tc.path)
+			if got != tc.want {
+				t.Errorf("resolveWorkingDir(%q) = %q, wanted %q", tc.path, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestResolveHomeRelative(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		config  *Config
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{
+			"not set passes relative path through",
+			&Config{HomePath: "/home/alice/notes", WorkingDir: "/home/alice/cwd", WorkingDirSet: false},
+			"work",
+			"work",
+			false,
+		},
+		{
+			"set re-expresses relative to HomePath",
+			&Config{HomePath: "/home/alice/notes", WorkingDir: "/home/alice/notes/cwd", WorkingDirSet: true},
+			"work",
+			"cwd/work",
+			false,
+		},
+		{
+			"set errors when the result escapes HomePath",
+			&Config{HomePath: "/home/alice/notes", WorkingDir: "/home/alice/cwd", WorkingDirSet: true},
+			"work",
+			"",
+			true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.config.resolveHomeRelative(tc.path)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("resolveHomeRelative(%q): expected an error", tc.path)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Errorf("resolveHomeRelative(%q) = %q, wanted %q", tc.path, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNewConfigWorkingDir(t *testing.T) {
+	home, err := ioutil.TempDir("", "notes-cli-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(home)
+	configDir, err := ioutil.TempDir("", "notes-cli-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(configDir)
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+
+	t.Run("working dir override marks WorkingDirSet", func(t *testing.T) {
+		config, err := NewConfig(home, "/some/dir")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !config.WorkingDirSet {
+			t.Error("WorkingDirSet = false, wanted true")
+		}
+		if config.WorkingDir != "/some/dir" {
+			t.Errorf("WorkingDir = %q, wanted '/some/dir'", config.WorkingDir)
+		}
+	})
+
+	t.Run("no override leaves WorkingDirSet false", func(t *testing.T) {
+		config, err := NewConfig(home, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if config.WorkingDirSet {
+			t.Error("WorkingDirSet = true, wanted false")
+		}
+	})
+}
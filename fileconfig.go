@@ -0,0 +1,74 @@
+package notes
+
+import (
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+	"io/ioutil"
+	"os"
+	"os/user"
+	"path/filepath"
+	"runtime"
+)
+
+// fileConfig is the schema of the persistent config file loaded from configPath(). Unlike Config, its
+// fields are all optional: anything left unset here keeps falling back to NOTES_CLI_* env vars and
+// command line flags, which always take precedence
+type fileConfig struct {
+	// Editor is the default value for Config.EditorCmd, used when $NOTES_CLI_EDITOR/$EDITOR are unset
+	Editor string `yaml:"editor"`
+	// Pager is the default value for Config.PagerCmd, used when $NOTES_CLI_PAGER/$PAGER are unset
+	Pager string `yaml:"pager"`
+	// Templates is a list of additional directories searched for shared templates
+	Templates []string `yaml:"templates"`
+	// Aliases maps a user-defined command name to the argv it expands to
+	Aliases map[string]string `yaml:"aliases"`
+}
+
+// configPath returns the directory notes-cli loads its persistent config file from. This is distinct
+// from homePath(), which locates the notebook's data directory: $XDG_CONFIG_HOME/notes-cli (or
+// ~/.config/notes-cli when unset) on Unix, %APPDATA%\notes-cli on Windows
+func configPath() (string, error) {
+	if runtime.GOOS == "windows" {
+		if env := os.Getenv("APPDATA"); env != "" {
+			return filepath.Join(env, "notes-cli"), nil
+		}
+	}
+
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		return filepath.Join(xdg, "notes-cli"), nil
+	}
+
+	u, err := user.Current()
+	if err != nil {
+		return "", errors.Wrap(err, "Cannot locate home directory to look up config file")
+	}
+	return filepath.Join(u.HomeDir, ".config", "notes-cli"), nil
+}
+
+// loadFileConfig loads the persistent config file from configPath(), trying config.yml then
+// config.yaml. A missing config file is not an error; it results in a zero-value fileConfig
+func loadFileConfig() (*fileConfig, error) {
+	dir, err := configPath()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range []string{"config.yml", "config.yaml"} {
+		path := filepath.Join(dir, name)
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, errors.Wrapf(err, "Cannot read config file '%s'", path)
+		}
+
+		fc := &fileConfig{}
+		if err := yaml.Unmarshal(b, fc); err != nil {
+			return nil, errors.Wrapf(err, "Cannot parse config file '%s'", path)
+		}
+		return fc, nil
+	}
+
+	return &fileConfig{}, nil
+}
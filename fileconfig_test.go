@@ -0,0 +1,78 @@
+package notes
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConfigPath(t *testing.T) {
+	dir, err := ioutil.TempDir("", "notes-cli-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	got, err := configPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := filepath.Join(dir, "notes-cli")
+	if got != want {
+		t.Errorf("configPath() = %q, wanted %q", got, want)
+	}
+}
+
+func TestLoadFileConfigMissing(t *testing.T) {
+	dir, err := ioutil.TempDir("", "notes-cli-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	fc, err := loadFileConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fc.Editor != "" || fc.Pager != "" || len(fc.Templates) != 0 || len(fc.Aliases) != 0 {
+		t.Errorf("loadFileConfig() = %+v, wanted zero value", fc)
+	}
+}
+
+func TestLoadFileConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "notes-cli-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	confDir := filepath.Join(dir, "notes-cli")
+	if err := os.MkdirAll(confDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	yml := "editor: vim\npager: less\ntemplates:\n  - /tmp/templates\naliases:\n  j: new journal daily\n"
+	if err := ioutil.WriteFile(filepath.Join(confDir, "config.yml"), []byte(yml), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	fc, err := loadFileConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fc.Editor != "vim" {
+		t.Errorf("Editor = %q, wanted 'vim'", fc.Editor)
+	}
+	if fc.Pager != "less" {
+		t.Errorf("Pager = %q, wanted 'less'", fc.Pager)
+	}
+	if len(fc.Templates) != 1 || fc.Templates[0] != "/tmp/templates" {
+		t.Errorf("Templates = %v, wanted ['/tmp/templates']", fc.Templates)
+	}
+	if fc.Aliases["j"] != "new journal daily" {
+		t.Errorf("Aliases[j] = %q, wanted 'new journal daily'", fc.Aliases["j"])
+	}
+}
@@ -0,0 +1,109 @@
+package notes
+
+import (
+	"bytes"
+	"github.com/pkg/errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// templateVars is the set of variables made available to a note template via Go's text/template
+type templateVars struct {
+	Title    string
+	Category string
+	Tags     string
+	Filename string
+	Date     string
+	ID       string
+	// Content holds stdin content read via --interactive/-i, made available to templates as {{.Content}}
+	Content string
+	Extra   map[string]string
+}
+
+// parseExtra parses a mapsep-style "key=val,key2=val2" string into a map of template variables.
+// Empty input returns an empty, non-nil map
+func parseExtra(s string) (map[string]string, error) {
+	m := map[string]string{}
+	if s == "" {
+		return m, nil
+	}
+	for _, kv := range strings.Split(s, ",") {
+		pair := strings.SplitN(kv, "=", 2)
+		if len(pair) != 2 {
+			return nil, errors.Errorf("Invalid --extra entry '%s'. Must be in 'key=value' form", kv)
+		}
+		m[pair[0]] = pair[1]
+	}
+	return m, nil
+}
+
+// templatesDir returns the default directory notes-cli looks up shared templates from:
+// configPath()/templates, i.e. $XDG_CONFIG_HOME/notes-cli/templates (or ~/.config/notes-cli/templates
+// when unset)
+func templatesDir() (string, error) {
+	dir, err := configPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "templates"), nil
+}
+
+// findTemplate resolves which template file should be used for a new note in category, in order of
+// priority: an explicit --template path, a per-category override at HomePath/category/.template.md, each
+// of config.TemplateDirs in order, and finally the default shared templates directory. An empty path
+// with a nil error means no template was found and the note should fall back to its default body.
+//
+// category is relative to HomePath, as NewCmd.resolveCategory returns it, so the per-category override
+// is looked up in that category's own directory. Shared templates are looked up by the category's final
+// path component only (filepath.Base), e.g. "work.md" for category "archive/work"
+func findTemplate(explicit, category string, config *Config) (string, error) {
+	if explicit != "" {
+		return explicit, nil
+	}
+
+	name := filepath.Base(category)
+
+	perCategory := filepath.Join(config.HomePath, category, ".template.md")
+	if _, err := os.Stat(perCategory); err == nil {
+		return perCategory, nil
+	}
+
+	dirs := append([]string{}, config.TemplateDirs...)
+	if dir, err := templatesDir(); err == nil {
+		dirs = append(dirs, dir)
+	} else {
+		return "", err
+	}
+
+	for _, dir := range dirs {
+		shared := filepath.Join(dir, name+".md")
+		if _, err := os.Stat(shared); err == nil {
+			return shared, nil
+		}
+	}
+
+	return "", nil
+}
+
+// renderTemplate renders the template file at path with vars using Go's text/template
+func renderTemplate(path string, vars *templateVars) (string, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", errors.Wrapf(err, "Cannot read template '%s'", path)
+	}
+
+	t, err := template.New(filepath.Base(path)).Parse(string(b))
+	if err != nil {
+		return "", errors.Wrapf(err, "Cannot parse template '%s'", path)
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, vars); err != nil {
+		return "", errors.Wrapf(err, "Cannot render template '%s'", path)
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,139 @@
+package notes
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseExtra(t *testing.T) {
+	for _, tc := range []struct {
+		in      string
+		want    map[string]string
+		wantErr bool
+	}{
+		{"", map[string]string{}, false},
+		{"key=val", map[string]string{"key": "val"}, false},
+		{"key=val,key2=val2", map[string]string{"key": "val", "key2": "val2"}, false},
+		{"key=a=b", map[string]string{"key": "a=b"}, false},
+		{"novalue", nil, true},
+	} {
+		got, err := parseExtra(tc.in)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("parseExtra(%q): expected an error", tc.in)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("parseExtra(%q) = %v, wanted %v", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestFindTemplate(t *testing.T) {
+	home, err := ioutil.TempDir("", "notes-cli-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(home)
+
+	configDir, err := ioutil.TempDir("", "notes-cli-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(configDir)
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+
+	sharedDir, err := ioutil.TempDir("", "notes-cli-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(sharedDir)
+	if err := ioutil.WriteFile(filepath.Join(sharedDir, "work.md"), []byte("shared"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	config := &Config{HomePath: home, TemplateDirs: []string{sharedDir}}
+
+	t.Run("explicit template takes precedence", func(t *testing.T) {
+		got, err := findTemplate("/some/explicit.md", "work", config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != "/some/explicit.md" {
+			t.Errorf("got %q, wanted explicit path", got)
+		}
+	})
+
+	t.Run("no template found", func(t *testing.T) {
+		got, err := findTemplate("", "private", config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != "" {
+			t.Errorf("got %q, wanted empty", got)
+		}
+	})
+
+	t.Run("shared template dir", func(t *testing.T) {
+		got, err := findTemplate("", "work", config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := filepath.Join(sharedDir, "work.md")
+		if got != want {
+			t.Errorf("got %q, wanted %q", got, want)
+		}
+	})
+
+	t.Run("per-category template takes precedence over shared", func(t *testing.T) {
+		if err := os.MkdirAll(filepath.Join(home, "work"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		perCategory := filepath.Join(home, "work", ".template.md")
+		if err := ioutil.WriteFile(perCategory, []byte("per-category"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		got, err := findTemplate("", "work", config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != perCategory {
+			t.Errorf("got %q, wanted %q", got, perCategory)
+		}
+	})
+}
+
+func TestRenderTemplate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "notes-cli-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "tmpl.md")
+	if err := ioutil.WriteFile(path, []byte("# {{.Title}}\n{{.Content}}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := renderTemplate(path, &templateVars{Title: "Hello", Content: "world"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "# Hello\nworld"
+	if got != want {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+}
+
+func TestRenderTemplateMissingFile(t *testing.T) {
+	if _, err := renderTemplate("/does/not/exist.md", &templateVars{}); err == nil {
+		t.Fatal("expected an error for a missing template file")
+	}
+}